@@ -1,6 +1,11 @@
 package logger
 
-type backend interface {
+// Backend represents a logging backend, responsible for writing formatted log entries to some destination.
+// Out-of-tree packages can implement Backend and register a factory for their configuration type with
+// RegisterBackend.
+type Backend interface {
 	Close()
-	Write(int, string, string, ...interface{})
+	Write(entry Entry)
+	SetLevel(level int)
+	Reopen() error
 }
@@ -0,0 +1,43 @@
+package logger
+
+// FileConfig represents a file logging backend configuration.
+type FileConfig struct {
+	// Path is the path to the log file. If empty or "-", messages are written to stderr.
+	Path string
+	// Format is the output format: "text" (default) or "json".
+	Format string
+	// Level is the minimum logging level honored by this backend (default "info").
+	Level string
+	// QueueSize is the number of pending messages buffered for this backend before writes start being dropped
+	// (default 4096).
+	QueueSize int
+	// MaxSizeBytes rotates the log file once it would exceed this size. Zero disables size-based rotation.
+	MaxSizeBytes int64
+	// MaxAgeSeconds rotates the log file once it is older than this many seconds. Zero disables age-based rotation.
+	MaxAgeSeconds int64
+	// MaxBackups is the number of rotated backups to keep; older ones are removed. Zero keeps them all.
+	MaxBackups int
+	// Compress gzips rotated backups.
+	Compress bool
+}
+
+func (c FileConfig) queueSize() int {
+	return c.QueueSize
+}
+
+// SyslogConfig represents a syslog logging backend configuration.
+type SyslogConfig struct {
+	// Tag is the syslog tag identifying the emitting process.
+	Tag string
+	// Facility is the syslog facility to log under (e.g. "daemon", "local0").
+	Facility string
+	// Level is the minimum logging level honored by this backend (default "info").
+	Level string
+	// QueueSize is the number of pending messages buffered for this backend before writes start being dropped
+	// (default 4096).
+	QueueSize int
+}
+
+func (c SyslogConfig) queueSize() int {
+	return c.QueueSize
+}
@@ -5,4 +5,7 @@ import "errors"
 var (
 	// ErrInvalidLevel represents an invalid logging level error.
 	ErrInvalidLevel = errors.New("invalid logging level")
+
+	// ErrUnsupportedBackend represents an unsupported logging backend configuration error.
+	ErrUnsupportedBackend = errors.New("unsupported logging backend")
 )
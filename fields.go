@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// formatFields renders a set of structured fields as a deterministic, space-separated "key=value" string suitable
+// for appending to a log line.
+func formatFields(fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	keys := fieldKeys(fields)
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%s=%v", key, fields[key])
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// formatFieldsSD renders a set of structured fields as an RFC5424 SD-ELEMENT, e.g. "[fields key=\"value\"]".
+func formatFieldsSD(fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	keys := fieldKeys(fields)
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%s=%q", key, fmt.Sprintf("%v", fields[key]))
+	}
+
+	return fmt.Sprintf("[fields %s]", strings.Join(parts, " "))
+}
+
+// fieldKeys returns the keys of fields sorted alphabetically, so rendered output is deterministic.
+func fieldKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
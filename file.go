@@ -2,33 +2,33 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path"
-	"strings"
-
-	"github.com/mgutz/ansi"
+	"sync/atomic"
+	"time"
 )
 
-var (
-	fileColors = map[int]string{
-		levelError:   "red",
-		levelWarning: "yellow",
-		levelNotice:  "magenta",
-		levelInfo:    "blue",
-		levelDebug:   "cyan",
-	}
-
-	fileLabels map[int]string
-)
+var fileColors = map[int]string{
+	LevelError:   "red",
+	LevelWarning: "yellow",
+	LevelNotice:  "magenta",
+	LevelInfo:    "blue",
+	LevelDebug:   "cyan",
+}
 
 type fileBackend struct {
-	logger *Logger
-	output *os.File
-	writer *log.Logger
+	logger    *Logger
+	output    *os.File
+	rotation  *fileRotation
+	writer    *log.Logger
+	formatter fileFormatter
+	level     int32
+	stopAge   chan struct{}
 }
 
-func newFileBackend(config FileConfig, logger *Logger) (backend, error) {
+func newFileBackend(config FileConfig, logger *Logger) (Backend, error) {
 	var (
 		output    *os.File
 		useColors bool
@@ -53,34 +53,118 @@ func newFileBackend(config FileConfig, logger *Logger) (backend, error) {
 		useColors = true
 	}
 
-	writer := log.New(output, "", log.LstdFlags|log.Lmicroseconds)
+	var formatter fileFormatter
 
-	// Initialize labels
-	fileLabels = map[int]string{}
+	switch config.Format {
+	case "", "text":
+		formatter = textFormatter{useColors: useColors}
+
+	case "json":
+		formatter = jsonFormatter{}
+
+	default:
+		return nil, fmt.Errorf("unsupported logging format: %s", config.Format)
+	}
+
+	level, err := parseLevel(config.Level)
+	if err != nil {
+		return nil, err
+	}
 
-	for name, level := range levelMap {
-		if useColors {
-			fileLabels[level] = ansi.Color(strings.ToUpper(name), fileColors[level])
-		} else {
-			fileLabels[level] = strings.ToUpper(name) + ":"
+	var (
+		rotation *fileRotation
+		dest     io.Writer = output
+	)
+
+	if config.Path != "" && config.Path != "-" {
+		rotation = &fileRotation{
+			file:       output,
+			path:       config.Path,
+			createdAt:  time.Now(),
+			maxSize:    config.MaxSizeBytes,
+			maxAge:     time.Duration(config.MaxAgeSeconds) * time.Second,
+			maxBackups: config.MaxBackups,
+			compress:   config.Compress,
 		}
+		dest = rotation
+	}
+
+	// Timestamps are rendered by the formatter itself from Entry.Time (the original call-site time), not by
+	// log.Logger, which would stamp at Print time on the backend's consumer goroutine instead.
+	writer := log.New(dest, "", 0)
+
+	b := &fileBackend{
+		logger:    logger,
+		output:    output,
+		rotation:  rotation,
+		writer:    writer,
+		formatter: formatter,
+		level:     int32(level),
+	}
+
+	if rotation != nil && config.MaxAgeSeconds > 0 {
+		b.stopAge = make(chan struct{})
+		go b.runAgeRotation(rotation.maxAge)
 	}
 
-	return &fileBackend{
-		logger: logger,
-		output: output,
-		writer: writer,
-	}, nil
+	return b, nil
 }
 
-func (b fileBackend) Close() {
+func (b *fileBackend) runAgeRotation(maxAge time.Duration) {
+	timer := time.NewTimer(maxAge)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			b.rotation.mu.Lock()
+			remaining := maxAge - time.Since(b.rotation.createdAt)
+			if remaining <= 0 {
+				_ = b.rotation.rotate()
+				remaining = maxAge
+			}
+			b.rotation.mu.Unlock()
+			timer.Reset(remaining)
+
+		case <-b.stopAge:
+			return
+		}
+	}
+}
+
+func (b *fileBackend) Close() {
+	if b.stopAge != nil {
+		close(b.stopAge)
+	}
+
+	if b.rotation != nil {
+		b.rotation.mu.Lock()
+		defer b.rotation.mu.Unlock()
+		b.rotation.file.Close()
+		return
+	}
+
 	b.output.Close()
 }
 
-func (b fileBackend) Write(level int, context, format string, v ...interface{}) {
-	if context != "" {
-		b.writer.Printf("%s %s: %s", fileLabels[level], context, fmt.Sprintf(format, v...))
-	} else {
-		b.writer.Printf("%s %s", fileLabels[level], fmt.Sprintf(format, v...))
+// Reopen closes and reopens the backend's file at its original path, for use after an external tool such as
+// logrotate has moved it aside. It is a no-op when logging to stderr.
+func (b *fileBackend) Reopen() error {
+	if b.rotation == nil {
+		return nil
 	}
+
+	return b.rotation.reopen()
+}
+
+func (b *fileBackend) Write(entry Entry) {
+	if entry.Level > int(atomic.LoadInt32(&b.level)) {
+		return
+	}
+
+	b.writer.Print(b.formatter.Format(entry.Time, entry.Level, entry.Context, entry.Fields, entry.Message))
+}
+
+func (b *fileBackend) SetLevel(level int) {
+	atomic.StoreInt32(&b.level, int32(level))
 }
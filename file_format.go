@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mgutz/ansi"
+)
+
+// fileFormatter renders a single log entry into the line written to a fileBackend's output. at is the time the
+// message was originally logged (the Logger.Error/Warning/.../Debug call site), not the time Format runs, since
+// Format runs on the backend's consumer goroutine and may be dequeued well after the fact under backpressure.
+type fileFormatter interface {
+	Format(at time.Time, level int, context string, fields map[string]interface{}, message string) string
+}
+
+// textFormatter renders log entries as the classic "TIME LABEL context: message key=value" line.
+type textFormatter struct {
+	useColors bool
+}
+
+func (f textFormatter) Format(at time.Time, level int, context string, fields map[string]interface{}, message string) string {
+	label := strings.ToUpper(levelNames[level])
+	if f.useColors {
+		label = ansi.Color(label, fileColors[level])
+	} else {
+		label += ":"
+	}
+
+	if context != "" {
+		message = fmt.Sprintf("%s: %s", context, message)
+	}
+
+	if len(fields) > 0 {
+		message = fmt.Sprintf("%s %s", message, formatFields(fields))
+	}
+
+	return fmt.Sprintf("%s %s %s", at.Format("2006/01/02 15:04:05.000000"), label, message)
+}
+
+// jsonFormatter renders log entries as a single JSON object per line, for ingestion by log shippers.
+type jsonFormatter struct{}
+
+func (jsonFormatter) Format(at time.Time, level int, context string, fields map[string]interface{}, message string) string {
+	entry := make(map[string]interface{}, len(fields)+4)
+	for k, v := range fields {
+		entry[k] = v
+	}
+
+	entry["time"] = at.Format(time.RFC3339Nano)
+	entry["level"] = levelNames[level]
+	entry["msg"] = message
+
+	if context != "" {
+		entry["context"] = context
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return message
+	}
+
+	return string(data)
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestJSONFormatterUsesGivenTime(t *testing.T) {
+	at := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	line := jsonFormatter{}.Format(at, LevelInfo, "", nil, "hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("Unmarshal(%q): %v", line, err)
+	}
+
+	if entry["time"] != at.Format(time.RFC3339Nano) {
+		t.Errorf("entry[\"time\"] = %v, want %s", entry["time"], at.Format(time.RFC3339Nano))
+	}
+}
+
+func TestTextFormatterUsesGivenTime(t *testing.T) {
+	at := time.Date(2024, 3, 1, 10, 30, 0, 123456000, time.UTC)
+
+	line := textFormatter{}.Format(at, LevelInfo, "", nil, "hello")
+
+	want := at.Format("2006/01/02 15:04:05.000000")
+	if len(line) < len(want) || line[:len(want)] != want {
+		t.Errorf("Format(...) = %q, want it to start with %q", line, want)
+	}
+}
+
+// slowWriter delays every Write by delay, to simulate a backend that can't keep up with the producer and lets a
+// backendQueue's buffer build up.
+type slowWriter struct {
+	buf   bytes.Buffer
+	delay time.Duration
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return w.buf.Write(p)
+}
+
+// TestLoggerEntryTimeSurvivesBackpressure checks that the timestamp recorded for a message reflects when it was
+// logged, not when a slow backend eventually got around to writing it.
+func TestLoggerEntryTimeSurvivesBackpressure(t *testing.T) {
+	w := &slowWriter{delay: 50 * time.Millisecond}
+
+	l, err := NewLogger(WriterConfig{W: w, Format: "json"})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	defer l.Close()
+
+	// The first message keeps the slow writer busy, so the second waits in the queue for the full delay.
+	l.Info("occupies the slow backend")
+	loggedAt := time.Now()
+	l.Info("queued behind a slow backend")
+	l.Flush()
+
+	lines := bytes.Split(bytes.TrimSpace(w.buf.Bytes()), []byte("\n"))
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(lines[len(lines)-1], &entry); err != nil {
+		t.Fatalf("Unmarshal(%q): %v", w.buf.String(), err)
+	}
+
+	recorded, err := time.Parse(time.RFC3339Nano, entry["time"].(string))
+	if err != nil {
+		t.Fatalf("Parse(%v): %v", entry["time"], err)
+	}
+
+	if skew := recorded.Sub(loggedAt); skew < 0 || skew > 25*time.Millisecond {
+		t.Errorf("recorded time skewed from call time by %s, want well under the backend's %s delay", skew, w.delay)
+	}
+}
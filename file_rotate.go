@@ -0,0 +1,221 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileRotation wraps the *os.File backing a fileBackend, tracking the bytes written and the file's age so it can
+// rotate it in place once a configured size or age threshold is crossed. It implements io.Writer so it can be
+// plugged directly into the backend's log.Logger as the write target.
+type fileRotation struct {
+	mu sync.Mutex
+
+	file *os.File
+	path string
+
+	size      int64
+	createdAt time.Time
+
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	compress   bool
+
+	// lastBackupStamp and backupSeq disambiguate backups created within the same wall-clock second. backupSeq only
+	// ever increases for a given stamp, regardless of what pruneBackups has since deleted, so a later rotation can
+	// never reuse a name a pruned-but-newer backup already held. The caller must hold r.mu.
+	lastBackupStamp string
+	backupSeq       int
+}
+
+func (r *fileRotation) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.maxSize > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+
+	return n, err
+}
+
+// rotate renames the current file aside (compressing it if configured), opens a fresh file at the original path,
+// and prunes backups beyond maxBackups. The caller must hold r.mu.
+func (r *fileRotation) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	backupPath := r.nextBackupPath(time.Now())
+	if err := os.Rename(r.path, backupPath); err != nil {
+		return err
+	}
+
+	if r.compress {
+		if err := compressBackup(backupPath); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	r.file = file
+	r.size = 0
+	r.createdAt = time.Now()
+
+	return r.pruneBackups()
+}
+
+// nextBackupPath returns r.path suffixed with at's timestamp, disambiguated with a numeric suffix when more than
+// one rotation lands within the same wall-clock second. The caller must hold r.mu.
+//
+// The first rotation for a given second probes the filesystem to skip past any backup a previous process run left
+// behind at that exact second. Every later rotation for that same second just keeps incrementing r.backupSeq
+// instead of probing again, so a name already handed out can never be reused once pruneBackups removes the file
+// it was written to — reusing it would let an older backup's name sort ahead of a newer one and get kept by
+// pruneBackups in its place.
+func (r *fileRotation) nextBackupPath(at time.Time) string {
+	stamp := at.Format("20060102-150405")
+
+	if stamp != r.lastBackupStamp {
+		r.lastBackupStamp = stamp
+		r.backupSeq = 0
+
+		for r.backupTaken(stamp, r.backupSeq) {
+			r.backupSeq++
+		}
+
+		return r.backupPathFor(stamp, r.backupSeq)
+	}
+
+	r.backupSeq++
+	return r.backupPathFor(stamp, r.backupSeq)
+}
+
+// backupPathFor builds the backup path for the given timestamp and sequence number, omitting the sequence suffix
+// for the common seq == 0 case.
+func (r *fileRotation) backupPathFor(stamp string, seq int) string {
+	if seq == 0 {
+		return fmt.Sprintf("%s.%s", r.path, stamp)
+	}
+
+	return fmt.Sprintf("%s.%s.%d", r.path, stamp, seq)
+}
+
+// backupTaken reports whether a backup already exists on disk for the given timestamp and sequence number. When
+// r.compress is set, it also accounts for the ".gz" file a prior rotation at that name would have left behind once
+// compressed.
+func (r *fileRotation) backupTaken(stamp string, seq int) bool {
+	backupPath := r.backupPathFor(stamp, seq)
+
+	if _, err := os.Stat(backupPath); err == nil {
+		return true
+	}
+
+	if r.compress {
+		if _, err := os.Stat(backupPath + ".gz"); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reopen closes and reopens the file at its original path without renaming it, for use when an external tool such
+// as logrotate has already moved it aside. The caller must not hold r.mu.
+func (r *fileRotation) reopen() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	r.file = file
+	r.size = 0
+	r.createdAt = time.Now()
+
+	return nil
+}
+
+// pruneBackups removes the oldest rotated backups once more than maxBackups are present. The caller must hold r.mu.
+func (r *fileRotation) pruneBackups() error {
+	if r.maxBackups <= 0 {
+		return nil
+	}
+
+	matches, err := filepath.Glob(r.path + ".*")
+	if err != nil {
+		return err
+	}
+
+	// Order backups oldest first: by timestamp, then numerically by sequence suffix, ignoring any ".gz" extension.
+	key := func(match string) string {
+		var seq int
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(match), filepath.Base(r.path)+"."), ".gz")
+		parts := strings.SplitN(name, ".", 2)
+		if len(parts) == 2 {
+			fmt.Sscanf(parts[1], "%d", &seq)
+		}
+		return fmt.Sprintf("%s.%09d", parts[0], seq)
+	}
+	sort.Slice(matches, func(i, j int) bool { return key(matches[i]) < key(matches[j]) })
+
+	if len(matches) <= r.maxBackups {
+		return nil
+	}
+
+	for _, old := range matches[:len(matches)-r.maxBackups] {
+		if err := os.Remove(old); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compressBackup gzips path in place, replacing it with path+".gz".
+func compressBackup(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	gzFile, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	defer gzFile.Close()
+
+	gzWriter := gzip.NewWriter(gzFile)
+
+	if _, err := gzWriter.Write(data); err != nil {
+		return err
+	}
+
+	if err := gzWriter.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestFileRotationSameSecondPruneKeepsNewest reproduces rotations landing within the same wall-clock second (the
+// common case under a MaxSizeBytes write burst) and checks that pruneBackups always keeps the most recently
+// written backup, even after an earlier backup with the same timestamp has already been pruned away.
+func TestFileRotationSameSecondPruneKeepsNewest(t *testing.T) {
+	dir := t.TempDir()
+
+	r := &fileRotation{
+		path:       filepath.Join(dir, "app.log"),
+		maxBackups: 1,
+	}
+
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	writeBackup := func(content string) string {
+		path := r.nextBackupPath(at)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile(%s): %v", path, err)
+		}
+
+		if err := r.pruneBackups(); err != nil {
+			t.Fatalf("pruneBackups: %v", err)
+		}
+
+		return path
+	}
+
+	writeBackup("first")
+	writeBackup("second")
+	third := writeBackup("third")
+
+	matches, err := filepath.Glob(r.path + ".*")
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+
+	if len(matches) != 1 {
+		t.Fatalf("backups on disk = %v, want exactly one", matches)
+	}
+
+	if matches[0] != third {
+		t.Fatalf("surviving backup = %s, want %s", matches[0], third)
+	}
+
+	data, err := os.ReadFile(third)
+	if err != nil {
+		t.Fatalf("ReadFile(%s): %v", third, err)
+	}
+
+	if got := string(data); got != "third" {
+		t.Fatalf("surviving backup content = %q, want %q", got, "third")
+	}
+}
+
+// TestFileRotationBackupPathsAreMonotonic checks that repeated calls for the same second never hand out a name
+// already returned earlier, regardless of what has since been deleted on disk.
+func TestFileRotationBackupPathsAreMonotonic(t *testing.T) {
+	dir := t.TempDir()
+
+	r := &fileRotation{path: filepath.Join(dir, "app.log")}
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	seen := map[string]bool{}
+	for i := 0; i < 5; i++ {
+		path := r.nextBackupPath(at)
+		if seen[path] {
+			t.Fatalf("nextBackupPath returned %s twice", path)
+		}
+		seen[path] = true
+
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatalf("WriteFile(%s): %v", path, err)
+		}
+
+		// Remove every backup as soon as it's written, mimicking aggressive pruning, and confirm that doesn't let a
+		// later call reuse an earlier name.
+		if err := os.Remove(path); err != nil {
+			t.Fatalf("Remove(%s): %v", path, err)
+		}
+	}
+}
@@ -2,7 +2,10 @@
 // and "debug".
 package logger
 
-import "sync"
+import (
+	"fmt"
+	"time"
+)
 
 const defaultLevel = "info"
 
@@ -28,46 +31,59 @@ var levelMap = map[string]int{
 	"debug":   LevelDebug,
 }
 
-// Logger represents a logger instance.
-type Logger struct {
-	backends []backend
-	context  string
+var levelNames = map[int]string{
+	LevelError:   "error",
+	LevelWarning: "warning",
+	LevelNotice:  "notice",
+	LevelInfo:    "info",
+	LevelDebug:   "debug",
+}
+
+// parseLevel returns the numeric logging level matching name, defaulting to defaultLevel when name is empty, or
+// ErrInvalidLevel if name does not match a known level.
+func parseLevel(name string) (int, error) {
+	if name == "" {
+		name = defaultLevel
+	}
 
-	wg sync.WaitGroup
+	level, ok := levelMap[name]
+	if !ok {
+		return 0, ErrInvalidLevel
+	}
+
+	return level, nil
+}
 
-	sync.Mutex
+// Logger represents a logger instance.
+type Logger struct {
+	queues  []*backendQueue
+	context string
+	fields  map[string]interface{}
 }
 
 // NewLogger returns a new Logger instance initialized with the given configuration.
 func NewLogger(configs ...interface{}) (*Logger, error) {
-	// Initialize logger backends
 	logger := &Logger{
-		backends: []backend{},
-		wg:       sync.WaitGroup{},
+		queues: []*backendQueue{},
 	}
 
 	for _, config := range configs {
-		var (
-			backend backend
-			err     error
-		)
-
-		switch config.(type) {
-		case FileConfig:
-			backend, err = newFileBackend(config.(FileConfig), logger)
-
-		case SyslogConfig:
-			backend, err = newSyslogBackend(config.(SyslogConfig), logger)
-
-		default:
-			err = ErrUnsupportedBackend
+		factory, ok := lookupBackendFactory(config)
+		if !ok {
+			return nil, ErrUnsupportedBackend
 		}
 
+		b, err := factory(config, logger)
 		if err != nil {
 			return nil, err
 		}
 
-		logger.backends = append(logger.backends, backend)
+		var queueSize int
+		if sizer, ok := config.(queueSizer); ok {
+			queueSize = sizer.queueSize()
+		}
+
+		logger.queues = append(logger.queues, newBackendQueue(b, queueSize))
 	}
 
 	return logger, nil
@@ -76,9 +92,31 @@ func NewLogger(configs ...interface{}) (*Logger, error) {
 // Context clones the Logger instance and sets the context to the provided string.
 func (l *Logger) Context(context string) *Logger {
 	return &Logger{
-		backends: l.backends,
-		context:  context,
-		wg:       sync.WaitGroup{},
+		queues:  l.queues,
+		context: context,
+		fields:  l.fields,
+	}
+}
+
+// With clones the Logger instance and attaches the given structured fields to it, so they are carried along with
+// every message the clone subsequently logs. Fields set on the clone are merged over any fields inherited from l.
+//
+// With must be called before a level method, e.g. logger.With(fields).Info(...), not after. Error/Warning/Notice/
+// Info/Debug write their message immediately and return l itself, not a fields-carrying clone, so fields attached
+// afterwards (logger.Info(...).With(fields)) are attached to a Logger that never logs again and are silently lost.
+func (l *Logger) With(fields map[string]interface{}) *Logger {
+	merged := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+
+	return &Logger{
+		queues:  l.queues,
+		context: l.context,
+		fields:  merged,
 	}
 }
 
@@ -112,25 +150,77 @@ func (l *Logger) Debug(format string, v ...interface{}) *Logger {
 	return l
 }
 
-// Close closes the logger output file.
+// Flush blocks until every message enqueued so far has been written to its backend. It is a no-op on backends
+// that have already been closed.
+func (l *Logger) Flush() {
+	for _, q := range l.queues {
+		q.flush()
+	}
+}
+
+// Close flushes every pending message and closes the logger's backends. It is safe to call more than once, and
+// concurrently with Error/Warning/Notice/Info/Debug: a message enqueued before a backend's queue is marked closed
+// is still written before that backend is closed, and a message arriving after is silently discarded.
 func (l *Logger) Close() {
-	for _, b := range l.backends {
-		b.Close()
+	l.Flush()
+
+	for _, q := range l.queues {
+		q.close()
 	}
 }
 
-func (l *Logger) write(level int, format string, v ...interface{}) {
-	l.Lock()
-	defer l.Unlock()
+// Reopen closes and reopens every backend's underlying file at its original path, for use after an external tool
+// such as logrotate has moved it aside (typically from a SIGHUP handler).
+func (l *Logger) Reopen() error {
+	for _, q := range l.queues {
+		if err := q.backend.Reopen(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-	l.wg.Add(len(l.backends))
+// SetLevel sets the minimum logging level honored by every backend attached to the Logger.
+func (l *Logger) SetLevel(name string) error {
+	level, err := parseLevel(name)
+	if err != nil {
+		return err
+	}
 
-	for _, b := range l.backends {
-		go func(b backend) {
-			b.Write(level, l.context, format, v...)
-			l.wg.Done()
-		}(b)
+	for _, q := range l.queues {
+		q.backend.SetLevel(level)
 	}
 
-	l.wg.Wait()
+	return nil
+}
+
+// SetBackendLevel sets the minimum logging level honored by the backend at index i.
+func (l *Logger) SetBackendLevel(i int, name string) error {
+	if i < 0 || i >= len(l.queues) {
+		return fmt.Errorf("backend index out of range: %d", i)
+	}
+
+	level, err := parseLevel(name)
+	if err != nil {
+		return err
+	}
+
+	l.queues[i].backend.SetLevel(level)
+
+	return nil
+}
+
+func (l *Logger) write(level int, format string, v ...interface{}) {
+	entry := Entry{
+		Level:   level,
+		Context: l.context,
+		Fields:  l.fields,
+		Message: fmt.Sprintf(format, v...),
+		Time:    time.Now(),
+	}
+
+	for _, q := range l.queues {
+		q.send(entry)
+	}
 }
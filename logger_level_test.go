@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestBackendLevelFiltersOnConfig checks that a backend configured with a minimum level drops messages below it.
+func TestBackendLevelFiltersOnConfig(t *testing.T) {
+	var buf bytes.Buffer
+
+	l, err := NewLogger(WriterConfig{W: &buf, Format: "text", Level: "warning"})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	defer l.Close()
+
+	l.Info("should be filtered out")
+	l.Error("should come through")
+	l.Flush()
+
+	out := buf.String()
+	if strings.Contains(out, "should be filtered out") {
+		t.Errorf("output contains a message below the configured level: %q", out)
+	}
+
+	if !strings.Contains(out, "should come through") {
+		t.Errorf("output is missing a message at the configured level: %q", out)
+	}
+}
+
+// TestSetLevelAppliesToEveryBackend checks that SetLevel raises (or lowers) the minimum level honored by every
+// backend attached to the Logger.
+func TestSetLevelAppliesToEveryBackend(t *testing.T) {
+	var buf bytes.Buffer
+
+	l, err := NewLogger(WriterConfig{W: &buf, Format: "text", Level: "error"})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	defer l.Close()
+
+	l.Info("dropped before SetLevel")
+	l.Flush()
+
+	if err := l.SetLevel("info"); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+
+	l.Info("kept after SetLevel")
+	l.Flush()
+
+	out := buf.String()
+	if strings.Contains(out, "dropped before SetLevel") {
+		t.Errorf("output contains a message logged before the level was raised: %q", out)
+	}
+
+	if !strings.Contains(out, "kept after SetLevel") {
+		t.Errorf("output is missing a message logged after the level was raised: %q", out)
+	}
+}
+
+// TestSetBackendLevelAppliesToOneBackend checks that SetBackendLevel only changes the backend at the given index.
+func TestSetBackendLevelAppliesToOneBackend(t *testing.T) {
+	var bufA, bufB bytes.Buffer
+
+	l, err := NewLogger(
+		WriterConfig{W: &bufA, Format: "text", Level: "error"},
+		WriterConfig{W: &bufB, Format: "text", Level: "error"},
+	)
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	defer l.Close()
+
+	if err := l.SetBackendLevel(0, "info"); err != nil {
+		t.Fatalf("SetBackendLevel: %v", err)
+	}
+
+	l.Info("only backend 0 should keep this")
+	l.Flush()
+
+	if !strings.Contains(bufA.String(), "only backend 0 should keep this") {
+		t.Errorf("backend 0 output is missing the message: %q", bufA.String())
+	}
+
+	if strings.Contains(bufB.String(), "only backend 0 should keep this") {
+		t.Errorf("backend 1 output should not contain the message: %q", bufB.String())
+	}
+}
+
+// TestSetBackendLevelOutOfRange checks that an out-of-range index is reported as an error rather than panicking.
+func TestSetBackendLevelOutOfRange(t *testing.T) {
+	l, err := NewLogger()
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	defer l.Close()
+
+	if err := l.SetBackendLevel(0, "info"); err == nil {
+		t.Error("SetBackendLevel(0, ...) on a Logger with no backends = nil error, want one")
+	}
+}
+
+// TestSetLevelInvalidName checks that an unknown level name is reported via ErrInvalidLevel.
+func TestSetLevelInvalidName(t *testing.T) {
+	l, err := NewLogger()
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	defer l.Close()
+
+	if err := l.SetLevel("bogus"); err != ErrInvalidLevel {
+		t.Errorf("SetLevel(\"bogus\") = %v, want ErrInvalidLevel", err)
+	}
+}
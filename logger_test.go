@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func newTestLogger(t *testing.T, buf *bytes.Buffer) *Logger {
+	t.Helper()
+
+	l, err := NewLogger(WriterConfig{W: buf, Format: "json"})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+
+	t.Cleanup(l.Close)
+
+	return l
+}
+
+// TestLoggerWithPrecursorAttachesFields checks that fields attached via With before a level call are carried
+// through to the written entry.
+func TestLoggerWithPrecursorAttachesFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestLogger(t, &buf)
+
+	l.With(map[string]interface{}{"path": "/tmp/f"}).Info("failed to open file")
+	l.Flush()
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("Unmarshal(%q): %v", buf.String(), err)
+	}
+
+	if entry["path"] != "/tmp/f" {
+		t.Errorf("entry[\"path\"] = %v, want /tmp/f", entry["path"])
+	}
+
+	if entry["msg"] != "failed to open file" {
+		t.Errorf("entry[\"msg\"] = %v, want %q", entry["msg"], "failed to open file")
+	}
+}
+
+// TestLoggerWithPostcursorIsNoop documents that With has no effect once called after a level method: the message
+// has already been written by the time With returns a fields-carrying clone, and nothing further logs through it.
+func TestLoggerWithPostcursorIsNoop(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestLogger(t, &buf)
+
+	l.Info("failed to open file").With(map[string]interface{}{"path": "/tmp/f"})
+	l.Flush()
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("Unmarshal(%q): %v", buf.String(), err)
+	}
+
+	if _, ok := entry["path"]; ok {
+		t.Errorf("entry contains \"path\" = %v, want it absent", entry["path"])
+	}
+}
+
+// TestLoggerWithMergesOverInheritedFields checks that fields set on a clone take precedence over fields inherited
+// from the Logger it was cloned from.
+func TestLoggerWithMergesOverInheritedFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestLogger(t, &buf)
+
+	base := l.With(map[string]interface{}{"path": "/tmp/f", "attempt": 1})
+	base.With(map[string]interface{}{"attempt": 2}).Info("retrying")
+	l.Flush()
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("Unmarshal(%q): %v", buf.String(), err)
+	}
+
+	if entry["path"] != "/tmp/f" {
+		t.Errorf("entry[\"path\"] = %v, want /tmp/f", entry["path"])
+	}
+
+	if entry["attempt"] != float64(2) {
+		t.Errorf("entry[\"attempt\"] = %v, want 2", entry["attempt"])
+	}
+}
+
+// TestLoggerContextPrefixesMessage checks that Context clones the Logger and prefixes subsequent messages.
+func TestLoggerContextPrefixesMessage(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestLogger(t, &buf)
+
+	l.Context("worker").Info("started")
+	l.Flush()
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("Unmarshal(%q): %v", buf.String(), err)
+	}
+
+	if entry["context"] != "worker" {
+		t.Errorf("entry[\"context\"] = %v, want worker", entry["context"])
+	}
+}
@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defaultQueueSize is the number of pending entries a backend queue buffers before writes start being dropped.
+	defaultQueueSize = 4096
+
+	// droppedWarnEvery controls how often a run of dropped messages is reported back through the queue.
+	droppedWarnEvery = 100
+)
+
+// Entry represents a single formatted log message handed to a Backend's Write method.
+type Entry struct {
+	Level   int
+	Context string
+	Fields  map[string]interface{}
+	Message string
+
+	// Time is when the message was logged, captured at the Logger.Error/Warning/.../Debug call site rather than
+	// when a backend eventually dequeues and writes it, so timestamps stay accurate under queue backpressure.
+	Time time.Time
+
+	// ack is non-nil for flush markers rather than messages to write: the consumer goroutine closes it once every
+	// entry enqueued ahead of it has been written, instead of passing it to the backend.
+	ack chan struct{}
+}
+
+// backendQueue pairs a backend with a buffered channel and the single goroutine draining it, so a slow backend
+// cannot block callers writing to the other backends attached to a Logger.
+type backendQueue struct {
+	backend Backend
+	entries chan Entry
+	done    chan struct{}
+	dropped int64
+
+	// closeMu and closed keep send/flush from racing close: close takes the write lock before closing entries, so
+	// it waits out any send/flush already past the closed check and in the channel op, and anything arriving after
+	// sees closed and no-ops instead of panicking on a closed channel. closeOnce makes close itself idempotent.
+	closeMu   sync.RWMutex
+	closed    bool
+	closeOnce sync.Once
+}
+
+func newBackendQueue(b Backend, size int) *backendQueue {
+	if size <= 0 {
+		size = defaultQueueSize
+	}
+
+	q := &backendQueue{
+		backend: b,
+		entries: make(chan Entry, size),
+		done:    make(chan struct{}),
+	}
+
+	go q.run()
+
+	return q
+}
+
+func (q *backendQueue) run() {
+	defer close(q.done)
+
+	for entry := range q.entries {
+		if entry.ack != nil {
+			close(entry.ack)
+			continue
+		}
+
+		q.backend.Write(entry)
+	}
+}
+
+// send enqueues entry without blocking. If the queue is full, entry is dropped and a dropped-message counter is
+// incremented; every droppedWarnEvery drops, a warning entry reporting the running total is enqueued if the queue
+// has room for it by then, and is discarded uncounted otherwise. send is a no-op once the queue has been closed.
+func (q *backendQueue) send(entry Entry) {
+	q.closeMu.RLock()
+	defer q.closeMu.RUnlock()
+
+	if q.closed {
+		return
+	}
+
+	select {
+	case q.entries <- entry:
+		return
+	default:
+	}
+
+	dropped := atomic.AddInt64(&q.dropped, 1)
+	if dropped%droppedWarnEvery != 0 {
+		return
+	}
+
+	warning := Entry{
+		Level:   LevelWarning,
+		Message: fmt.Sprintf("%d messages dropped: backend queue is full", dropped),
+		Time:    time.Now(),
+	}
+
+	select {
+	case q.entries <- warning:
+	default:
+	}
+}
+
+// flush blocks until every entry enqueued ahead of it has been written. It is a no-op once the queue has been
+// closed.
+func (q *backendQueue) flush() {
+	q.closeMu.RLock()
+	defer q.closeMu.RUnlock()
+
+	if q.closed {
+		return
+	}
+
+	ack := make(chan struct{})
+	q.entries <- Entry{ack: ack}
+	<-ack
+}
+
+// close drains and stops the queue, then closes the underlying backend. It is safe to call more than once; only
+// the first call has any effect.
+func (q *backendQueue) close() {
+	q.closeOnce.Do(func() {
+		q.closeMu.Lock()
+		q.closed = true
+		q.closeMu.Unlock()
+
+		close(q.entries)
+		<-q.done
+		q.backend.Close()
+	})
+}
@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+)
+
+// fakeBackend records the entries it receives. The first call to Write blocks until release is closed, which lets
+// tests fill the queue's buffer deterministically before the consumer goroutine drains it further.
+type fakeBackend struct {
+	mu      sync.Mutex
+	written []Entry
+	closed  bool
+
+	release  chan struct{}
+	writing  chan struct{}
+	blockNth int
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{
+		release:  make(chan struct{}),
+		writing:  make(chan struct{}, 1),
+		blockNth: 1,
+	}
+}
+
+func (f *fakeBackend) Write(entry Entry) {
+	f.mu.Lock()
+	f.blockNth--
+	block := f.blockNth == 0
+	f.mu.Unlock()
+
+	if block {
+		select {
+		case f.writing <- struct{}{}:
+		default:
+		}
+		<-f.release
+	}
+
+	f.mu.Lock()
+	f.written = append(f.written, entry)
+	f.mu.Unlock()
+}
+
+func (f *fakeBackend) SetLevel(level int) {}
+func (f *fakeBackend) Reopen() error      { return nil }
+
+func (f *fakeBackend) Close() {
+	f.mu.Lock()
+	f.closed = true
+	f.mu.Unlock()
+}
+
+func (f *fakeBackend) messages() []Entry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]Entry(nil), f.written...)
+}
+
+// TestBackendQueueDropsWhenFull forces the consumer goroutine to stall inside backend.Write while the caller keeps
+// sending, and checks that once the buffered channel fills, further sends are dropped and counted rather than
+// blocking the caller.
+func TestBackendQueueDropsWhenFull(t *testing.T) {
+	backend := newFakeBackend()
+	q := newBackendQueue(backend, 2)
+
+	q.send(Entry{Message: "1"})
+	<-backend.writing // consumer has dequeued "1" and is now stuck in Write
+
+	q.send(Entry{Message: "2"})
+	q.send(Entry{Message: "3"})
+
+	// The channel buffer (size 2) is now full, so this one must be dropped rather than block.
+	q.send(Entry{Message: "4"})
+
+	if got := q.dropped; got != 1 {
+		t.Fatalf("dropped = %d, want 1", got)
+	}
+
+	close(backend.release)
+	q.flush()
+	q.close()
+
+	messages := backend.messages()
+	if len(messages) != 3 {
+		t.Fatalf("len(messages) = %d, want 3: %v", len(messages), messages)
+	}
+
+	for i, want := range []string{"1", "2", "3"} {
+		if messages[i].Message != want {
+			t.Errorf("messages[%d] = %q, want %q", i, messages[i].Message, want)
+		}
+	}
+}
+
+// TestBackendQueueFlushWaitsForPriorEntries checks that flush does not return until every entry sent ahead of it
+// has reached the backend.
+func TestBackendQueueFlushWaitsForPriorEntries(t *testing.T) {
+	backend := newFakeBackend()
+	backend.blockNth = 0 // never block, so sends reach the backend as fast as the consumer can drain them
+	q := newBackendQueue(backend, 8)
+
+	for _, msg := range []string{"a", "b", "c"} {
+		q.send(Entry{Message: msg})
+	}
+
+	q.flush()
+
+	messages := backend.messages()
+	if len(messages) != 3 {
+		t.Fatalf("len(messages) = %d, want 3: %v", len(messages), messages)
+	}
+
+	q.close()
+
+	if !backend.closed {
+		t.Error("backend.Close was not called by q.close")
+	}
+}
+
+// TestBackendQueueCloseIsIdempotent checks that calling close more than once does not panic (it used to, closing
+// the already-closed entries channel a second time).
+func TestBackendQueueCloseIsIdempotent(t *testing.T) {
+	backend := newFakeBackend()
+	backend.blockNth = 0
+	q := newBackendQueue(backend, 4)
+
+	q.close()
+	q.close()
+	q.close()
+}
+
+// TestBackendQueueSendDuringClose sends from another goroutine while close runs concurrently, and checks that
+// neither panics (send used to race close's "close(q.entries)" and panic with "send on closed channel").
+func TestBackendQueueSendDuringClose(t *testing.T) {
+	backend := newFakeBackend()
+	backend.blockNth = 0
+	q := newBackendQueue(backend, 4)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			q.send(Entry{Message: "hot path"})
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		q.close()
+	}()
+
+	wg.Wait()
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"reflect"
+	"sync"
+)
+
+// queueSizer is implemented by backend configurations that want to customize their queue buffer size (see
+// backendQueue). Configurations that do not implement it get defaultQueueSize.
+type queueSizer interface {
+	queueSize() int
+}
+
+var (
+	backendFactoriesMu sync.RWMutex
+	backendFactories   = map[reflect.Type]func(config interface{}, logger *Logger) (Backend, error){}
+)
+
+func init() {
+	RegisterBackend(FileConfig{}, func(config interface{}, logger *Logger) (Backend, error) {
+		return newFileBackend(config.(FileConfig), logger)
+	})
+
+	RegisterBackend(SyslogConfig{}, func(config interface{}, logger *Logger) (Backend, error) {
+		return newSyslogBackend(config.(SyslogConfig), logger)
+	})
+
+	RegisterBackend(WriterConfig{}, func(config interface{}, logger *Logger) (Backend, error) {
+		return newWriterBackend(config.(WriterConfig), logger)
+	})
+}
+
+// RegisterBackend registers factory as the constructor for backends configured with values of the same type as
+// sample, keyed by reflect.TypeOf(sample). This lets external packages plug their own logging backend into
+// NewLogger without forking the package. It is safe to call concurrently with itself and with NewLogger.
+func RegisterBackend(sample interface{}, factory func(config interface{}, logger *Logger) (Backend, error)) {
+	backendFactoriesMu.Lock()
+	defer backendFactoriesMu.Unlock()
+
+	backendFactories[reflect.TypeOf(sample)] = factory
+}
+
+// lookupBackendFactory returns the factory registered for config's type, if any.
+func lookupBackendFactory(config interface{}) (func(config interface{}, logger *Logger) (Backend, error), bool) {
+	backendFactoriesMu.RLock()
+	defer backendFactoriesMu.RUnlock()
+
+	factory, ok := backendFactories[reflect.TypeOf(config)]
+	return factory, ok
+}
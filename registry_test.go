@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+)
+
+// testBackendConfig is a standalone config type, the shape an out-of-tree package registering its own backend
+// would use.
+type testBackendConfig struct {
+	sink *[]Entry
+}
+
+type testBackend struct {
+	sink *[]Entry
+}
+
+func (b *testBackend) Write(entry Entry) { *b.sink = append(*b.sink, entry) }
+func (b *testBackend) Close()            {}
+func (b *testBackend) SetLevel(int)      {}
+func (b *testBackend) Reopen() error     { return nil }
+
+// TestRegisterBackendCustomType checks that a backend registered by an external package is picked up by NewLogger
+// and actually receives writes, the way RegisterBackend is meant to be used.
+func TestRegisterBackendCustomType(t *testing.T) {
+	RegisterBackend(testBackendConfig{}, func(config interface{}, logger *Logger) (Backend, error) {
+		cfg := config.(testBackendConfig)
+		return &testBackend{sink: cfg.sink}, nil
+	})
+
+	var sink []Entry
+
+	l, err := NewLogger(testBackendConfig{sink: &sink})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	defer l.Close()
+
+	l.Info("via custom backend")
+	l.Flush()
+
+	if len(sink) != 1 || sink[0].Message != "via custom backend" {
+		t.Errorf("sink = %v, want a single entry with message %q", sink, "via custom backend")
+	}
+}
+
+// TestNewLoggerUnsupportedBackend checks that a config type with no registered factory is reported via
+// ErrUnsupportedBackend instead of panicking.
+func TestNewLoggerUnsupportedBackend(t *testing.T) {
+	type unregisteredConfig struct{}
+
+	if _, err := NewLogger(unregisteredConfig{}); err != ErrUnsupportedBackend {
+		t.Errorf("NewLogger(unregisteredConfig{}) error = %v, want ErrUnsupportedBackend", err)
+	}
+}
+
+// TestRegisterBackendConcurrent registers and looks up backends from multiple goroutines at once, so `go test
+// -race` catches any reintroduced unsynchronized access to backendFactories.
+func TestRegisterBackendConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+			RegisterBackend(testBackendConfig{}, func(config interface{}, logger *Logger) (Backend, error) {
+				cfg := config.(testBackendConfig)
+				return &testBackend{sink: cfg.sink}, nil
+			})
+		}(i)
+
+		go func() {
+			defer wg.Done()
+			var sink []Entry
+			if _, err := NewLogger(testBackendConfig{sink: &sink}); err != nil {
+				t.Errorf("NewLogger: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,107 @@
+//go:build !windows
+// +build !windows
+
+package logger
+
+import (
+	"fmt"
+	"log/syslog"
+	"sync/atomic"
+)
+
+var syslogFacilities = map[string]syslog.Priority{
+	"kern":     syslog.LOG_KERN,
+	"user":     syslog.LOG_USER,
+	"mail":     syslog.LOG_MAIL,
+	"daemon":   syslog.LOG_DAEMON,
+	"auth":     syslog.LOG_AUTH,
+	"syslog":   syslog.LOG_SYSLOG,
+	"lpr":      syslog.LOG_LPR,
+	"news":     syslog.LOG_NEWS,
+	"uucp":     syslog.LOG_UUCP,
+	"cron":     syslog.LOG_CRON,
+	"authpriv": syslog.LOG_AUTHPRIV,
+	"ftp":      syslog.LOG_FTP,
+	"local0":   syslog.LOG_LOCAL0,
+	"local1":   syslog.LOG_LOCAL1,
+	"local2":   syslog.LOG_LOCAL2,
+	"local3":   syslog.LOG_LOCAL3,
+	"local4":   syslog.LOG_LOCAL4,
+	"local5":   syslog.LOG_LOCAL5,
+	"local6":   syslog.LOG_LOCAL6,
+	"local7":   syslog.LOG_LOCAL7,
+}
+
+type syslogBackend struct {
+	logger *Logger
+	writer *syslog.Writer
+	level  int32
+}
+
+func newSyslogBackend(config SyslogConfig, logger *Logger) (Backend, error) {
+	facility, ok := syslogFacilities[config.Facility]
+	if !ok {
+		facility = syslog.LOG_DAEMON
+	}
+
+	level, err := parseLevel(config.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	writer, err := syslog.New(facility, config.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open syslog: %s", err)
+	}
+
+	return &syslogBackend{
+		logger: logger,
+		writer: writer,
+		level:  int32(level),
+	}, nil
+}
+
+func (b *syslogBackend) Close() {
+	b.writer.Close()
+}
+
+func (b *syslogBackend) SetLevel(level int) {
+	atomic.StoreInt32(&b.level, int32(level))
+}
+
+// Reopen is a no-op for syslogBackend: the syslog connection does not need reopening.
+func (b *syslogBackend) Reopen() error {
+	return nil
+}
+
+func (b *syslogBackend) Write(entry Entry) {
+	if entry.Level > int(atomic.LoadInt32(&b.level)) {
+		return
+	}
+
+	message := entry.Message
+	if entry.Context != "" {
+		message = fmt.Sprintf("%s: %s", entry.Context, message)
+	}
+
+	if len(entry.Fields) > 0 {
+		message = fmt.Sprintf("%s %s", formatFieldsSD(entry.Fields), message)
+	}
+
+	switch entry.Level {
+	case LevelError:
+		b.writer.Err(message)
+
+	case LevelWarning:
+		b.writer.Warning(message)
+
+	case LevelNotice:
+		b.writer.Notice(message)
+
+	case LevelInfo:
+		b.writer.Info(message)
+
+	case LevelDebug:
+		b.writer.Debug(message)
+	}
+}
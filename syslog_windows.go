@@ -0,0 +1,8 @@
+//go:build windows
+// +build windows
+
+package logger
+
+func newSyslogBackend(config SyslogConfig, logger *Logger) (Backend, error) {
+	return nil, ErrUnsupportedBackend
+}
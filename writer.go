@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// WriterConfig represents a logging backend configuration that wraps an arbitrary io.Writer, e.g. a bytes.Buffer
+// for in-memory testing, or an adapter bridging into testing.T.Log.
+type WriterConfig struct {
+	// W is the destination every log message is written to.
+	W io.Writer
+	// Format is the output format: "text" (default) or "json".
+	Format string
+	// Level is the minimum logging level honored by this backend (default "info").
+	Level string
+}
+
+type writerBackend struct {
+	output    io.Writer
+	formatter fileFormatter
+	level     int32
+}
+
+func newWriterBackend(config WriterConfig, logger *Logger) (Backend, error) {
+	if config.W == nil {
+		return nil, fmt.Errorf("writer backend requires a non-nil io.Writer")
+	}
+
+	var formatter fileFormatter
+
+	switch config.Format {
+	case "", "text":
+		formatter = textFormatter{}
+
+	case "json":
+		formatter = jsonFormatter{}
+
+	default:
+		return nil, fmt.Errorf("unsupported logging format: %s", config.Format)
+	}
+
+	level, err := parseLevel(config.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	return &writerBackend{
+		output:    config.W,
+		formatter: formatter,
+		level:     int32(level),
+	}, nil
+}
+
+func (b *writerBackend) Close() {}
+
+func (b *writerBackend) Reopen() error {
+	return nil
+}
+
+func (b *writerBackend) SetLevel(level int) {
+	atomic.StoreInt32(&b.level, int32(level))
+}
+
+func (b *writerBackend) Write(entry Entry) {
+	if entry.Level > int(atomic.LoadInt32(&b.level)) {
+		return
+	}
+
+	line := b.formatter.Format(entry.Time, entry.Level, entry.Context, entry.Fields, entry.Message)
+
+	fmt.Fprintln(b.output, line)
+}
@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNewLoggerWriterConfigRequiresWriter(t *testing.T) {
+	if _, err := NewLogger(WriterConfig{}); err == nil {
+		t.Error("NewLogger(WriterConfig{}) error = nil, want an error for a nil W")
+	}
+}
+
+func TestNewLoggerWriterConfigUnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	if _, err := NewLogger(WriterConfig{W: &buf, Format: "xml"}); err == nil {
+		t.Error("NewLogger with Format \"xml\" error = nil, want an error")
+	}
+}
+
+// TestWriterBackendReopenIsNoop checks that Reopen on a writer backend, which has no file to reopen, is a no-op
+// rather than an error.
+func TestWriterBackendReopenIsNoop(t *testing.T) {
+	var buf bytes.Buffer
+
+	l, err := NewLogger(WriterConfig{W: &buf})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	defer l.Close()
+
+	if err := l.Reopen(); err != nil {
+		t.Errorf("Reopen() = %v, want nil", err)
+	}
+}